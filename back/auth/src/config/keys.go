@@ -0,0 +1,174 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// SigningKey는 JWT 서명에 사용하는 RSA 키 쌍과 공개 식별자(kid)를 담는다.
+type SigningKey struct {
+	KID        string
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+	VerifyOnly bool // true면 회전되어 더 이상 서명에는 쓰이지 않고 검증 용도로만 남는다
+}
+
+var (
+	signingKeysMu sync.RWMutex
+	currentKey    *SigningKey
+	previousKeys  []*SigningKey // 회전 유예 기간 동안 검증용으로 유지되는 이전 키들
+)
+
+// InitSigningKeys는 JWT_PRIVATE_KEY_PATH가 가리키는 로컬 파일(PKCS#1 PEM)에서 서명
+// 키를 로드하거나, 설정되어 있지 않으면 새 키를 생성한다. KMS 참조는 지원하지 않으며
+// 경로는 항상 파일로 읽는다. JWT_ROTATION_INTERVAL이
+// 설정된 경우 해당 주기로 키 회전을 백그라운드에서 수행한다.
+func InitSigningKeys() error {
+	key, err := loadOrGenerateKey(os.Getenv("JWT_PRIVATE_KEY_PATH"))
+	if err != nil {
+		return fmt.Errorf("failed to init signing key: %w", err)
+	}
+
+	signingKeysMu.Lock()
+	currentKey = key
+	signingKeysMu.Unlock()
+
+	if interval := os.Getenv("JWT_ROTATION_INTERVAL"); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return fmt.Errorf("invalid JWT_ROTATION_INTERVAL: %w", err)
+		}
+
+		grace := 24 * time.Hour
+		if g := os.Getenv("JWT_ROTATION_GRACE_PERIOD"); g != "" {
+			gd, err := time.ParseDuration(g)
+			if err != nil {
+				return fmt.Errorf("invalid JWT_ROTATION_GRACE_PERIOD: %w", err)
+			}
+			grace = gd
+		}
+
+		go rotateKeysPeriodically(d, grace)
+	}
+
+	log.Printf("Signing key initialized (kid=%s)", currentKey.KID)
+	return nil
+}
+
+func loadOrGenerateKey(path string) (*SigningKey, error) {
+	if path == "" {
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, err
+		}
+		return newSigningKey(priv), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM in %s", path)
+	}
+
+	priv, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return newSigningKey(priv), nil
+}
+
+func newSigningKey(priv *rsa.PrivateKey) *SigningKey {
+	sum := sha256.Sum256(priv.PublicKey.N.Bytes())
+	return &SigningKey{
+		KID:        hex.EncodeToString(sum[:])[:16],
+		PrivateKey: priv,
+		PublicKey:  &priv.PublicKey,
+	}
+}
+
+func rotateKeysPeriodically(interval, grace time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		priv, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			log.Printf("key rotation failed: %v", err)
+			continue
+		}
+		newKey := newSigningKey(priv)
+
+		signingKeysMu.Lock()
+		retired := currentKey
+		retired.VerifyOnly = true
+		currentKey = newKey
+		previousKeys = append(previousKeys, retired)
+		signingKeysMu.Unlock()
+
+		log.Printf("rotated signing key (new kid=%s, retired kid=%s)", newKey.KID, retired.KID)
+		go evictAfterGrace(retired.KID, grace)
+	}
+}
+
+func evictAfterGrace(kid string, grace time.Duration) {
+	time.Sleep(grace)
+
+	signingKeysMu.Lock()
+	defer signingKeysMu.Unlock()
+	for i, k := range previousKeys {
+		if k.KID == kid {
+			previousKeys = append(previousKeys[:i], previousKeys[i+1:]...)
+			break
+		}
+	}
+	log.Printf("evicted retired signing key (kid=%s)", kid)
+}
+
+// CurrentSigningKey는 현재 서명에 사용할 키를 반환한다.
+func CurrentSigningKey() *SigningKey {
+	signingKeysMu.RLock()
+	defer signingKeysMu.RUnlock()
+	return currentKey
+}
+
+// SigningKeyByKID는 주어진 kid에 해당하는 키(서명용 또는 검증 전용)를 찾는다.
+func SigningKeyByKID(kid string) *SigningKey {
+	signingKeysMu.RLock()
+	defer signingKeysMu.RUnlock()
+
+	if currentKey != nil && currentKey.KID == kid {
+		return currentKey
+	}
+	for _, k := range previousKeys {
+		if k.KID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// AllVerifyingKeys는 JWKS 엔드포인트에 노출할 현재 키 + 이전 키 목록을 반환한다.
+func AllVerifyingKeys() []*SigningKey {
+	signingKeysMu.RLock()
+	defer signingKeysMu.RUnlock()
+
+	keys := make([]*SigningKey, 0, len(previousKeys)+1)
+	if currentKey != nil {
+		keys = append(keys, currentKey)
+	}
+	keys = append(keys, previousKeys...)
+	return keys
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+	"golang.org/x/oauth2/google"
+)
+
+// OAuthProvider는 하나의 소셜/OIDC 로그인 제공자에 필요한 oauth2 설정과
+// 사용자 정보를 가져올 UserInfo 엔드포인트를 묶어서 들고 있는다.
+type OAuthProvider struct {
+	Config      *oauth2.Config
+	UserInfoURL string
+}
+
+// OAuthProviders는 등록된 프로바이더 이름("google", "github", "oidc" 등) ->
+// 설정 맵이다. 새 프로바이더는 InitOAuthProviders에 분기를 추가하는 것만으로
+// 지원되며 핸들러 코드는 바뀌지 않는다.
+var OAuthProviders = map[string]*OAuthProvider{}
+
+// InitOAuthProviders는 환경변수에 설정된 프로바이더만 등록한다.
+func InitOAuthProviders() {
+	if id := os.Getenv("OAUTH_GOOGLE_CLIENT_ID"); id != "" {
+		OAuthProviders["google"] = &OAuthProvider{
+			Config: &oauth2.Config{
+				ClientID:     id,
+				ClientSecret: os.Getenv("OAUTH_GOOGLE_CLIENT_SECRET"),
+				RedirectURL:  os.Getenv("OAUTH_GOOGLE_REDIRECT_URL"),
+				Scopes:       []string{"openid", "email", "profile"},
+				Endpoint:     google.Endpoint,
+			},
+			UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+		}
+	}
+
+	if id := os.Getenv("OAUTH_GITHUB_CLIENT_ID"); id != "" {
+		OAuthProviders["github"] = &OAuthProvider{
+			Config: &oauth2.Config{
+				ClientID:     id,
+				ClientSecret: os.Getenv("OAUTH_GITHUB_CLIENT_SECRET"),
+				RedirectURL:  os.Getenv("OAUTH_GITHUB_REDIRECT_URL"),
+				Scopes:       []string{"read:user", "user:email"},
+				Endpoint:     github.Endpoint,
+			},
+			UserInfoURL: "https://api.github.com/user",
+		}
+	}
+
+	// 제네릭 OIDC 프로바이더: 엔드포인트까지 전부 환경변수로 받는다
+	if id := os.Getenv("OAUTH_OIDC_CLIENT_ID"); id != "" {
+		OAuthProviders["oidc"] = &OAuthProvider{
+			Config: &oauth2.Config{
+				ClientID:     id,
+				ClientSecret: os.Getenv("OAUTH_OIDC_CLIENT_SECRET"),
+				RedirectURL:  os.Getenv("OAUTH_OIDC_REDIRECT_URL"),
+				Scopes:       []string{"openid", "email", "profile"},
+				Endpoint: oauth2.Endpoint{
+					AuthURL:  os.Getenv("OAUTH_OIDC_AUTH_URL"),
+					TokenURL: os.Getenv("OAUTH_OIDC_TOKEN_URL"),
+				},
+			},
+			UserInfoURL: os.Getenv("OAUTH_OIDC_USERINFO_URL"),
+		}
+	}
+
+	log.Printf("Registered %d OAuth provider(s)", len(OAuthProviders))
+}
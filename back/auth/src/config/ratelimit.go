@@ -0,0 +1,64 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit은 "N/window" 형태(예: "5/1m")로 설정되는 sliding-window 한도 하나를 나타낸다.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+type RateLimitsConfig struct {
+	Login    RateLimit // IP + email
+	Refresh  RateLimit // IP + email
+	Register RateLimit // IP
+	Verify   RateLimit // user ID
+	MFA      RateLimit // user ID
+}
+
+var RateLimits RateLimitsConfig
+
+// InitRateLimits는 RATELIMIT_* 환경변수를 읽어 각 엔드포인트의 한도를 설정한다.
+// 값이 없거나 형식이 잘못되면 기본값으로 대체한다.
+func InitRateLimits() {
+	RateLimits = RateLimitsConfig{
+		Login:    parseRateLimit("RATELIMIT_LOGIN", RateLimit{Limit: 5, Window: time.Minute}),
+		Refresh:  parseRateLimit("RATELIMIT_REFRESH", RateLimit{Limit: 20, Window: time.Minute}),
+		Register: parseRateLimit("RATELIMIT_REGISTER", RateLimit{Limit: 10, Window: time.Hour}),
+		Verify:   parseRateLimit("RATELIMIT_VERIFY", RateLimit{Limit: 300, Window: time.Minute}),
+		MFA:      parseRateLimit("RATELIMIT_MFA", RateLimit{Limit: 10, Window: time.Minute}),
+	}
+}
+
+func parseRateLimit(envKey string, def RateLimit) RateLimit {
+	spec := os.Getenv(envKey)
+	if spec == "" {
+		return def
+	}
+
+	parts := strings.SplitN(spec, "/", 2)
+	if len(parts) != 2 {
+		log.Printf("invalid %s=%q, using default %+v", envKey, spec, def)
+		return def
+	}
+
+	limit, err := strconv.Atoi(parts[0])
+	if err != nil {
+		log.Printf("invalid %s=%q, using default %+v", envKey, spec, def)
+		return def
+	}
+
+	window, err := time.ParseDuration(parts[1])
+	if err != nil {
+		log.Printf("invalid %s=%q, using default %+v", envKey, spec, def)
+		return def
+	}
+
+	return RateLimit{Limit: limit, Window: window}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"auth-server/src/models"
+	"auth-server/src/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// ListUsers는 관리자용 사용자 목록을 반환한다.
+func ListUsers(c *gin.Context) {
+	users, err := models.ListUsers()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// GetUser는 단일 사용자의 정보와 역할을 반환한다.
+func GetUser(c *gin.Context) {
+	id, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := models.GetUserByID(id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	roles, err := models.GetUserRoles(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load roles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user": user, "roles": roles})
+}
+
+// DisableUser는 계정을 정지시키고 모든 세션을 강제 로그아웃시킨다.
+func DisableUser(c *gin.Context) {
+	id, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := models.SetUserDisabled(id, true); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable user"})
+		return
+	}
+
+	_ = utils.RevokeRefreshToken(id, "")
+	utils.LogAuditEvent(&id, "admin.user_disabled", c.ClientIP(), c.Request.UserAgent(), nil)
+	c.JSON(http.StatusOK, gin.H{"message": "User disabled"})
+}
+
+// ForceLogoutUser는 계정 상태는 그대로 두고 모든 기기의 세션만 무효화한다.
+func ForceLogoutUser(c *gin.Context) {
+	id, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := utils.RevokeRefreshToken(id, ""); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to force logout"})
+		return
+	}
+
+	utils.LogAuditEvent(&id, "admin.force_logout", c.ClientIP(), c.Request.UserAgent(), nil)
+	c.JSON(http.StatusOK, gin.H{"message": "User logged out from all sessions"})
+}
+
+// AssignUserRole은 사용자에게 역할을 부여한다 (없는 역할이면 새로 만든다).
+func AssignUserRole(c *gin.Context) {
+	id, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := models.AssignRole(id, req.Role); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign role"})
+		return
+	}
+
+	utils.LogAuditEvent(&id, "admin.role_assigned", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"role": req.Role})
+	c.JSON(http.StatusOK, gin.H{"message": "Role assigned"})
+}
+
+func parseUserIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user id"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"auth-server/src/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListAuditEvents는 관리자가 user_id, event_type, since/until 쿼리 파라미터로
+// 감사 로그를 필터링해 조회할 수 있게 한다.
+func ListAuditEvents(c *gin.Context) {
+	filter := models.AuditEventFilter{
+		EventType: c.Query("event_type"),
+	}
+
+	if v := c.Query("user_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		filter.UserID = &id
+	}
+
+	if v := c.Query("since"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since (expected RFC3339)"})
+			return
+		}
+		filter.Since = &t
+	}
+
+	if v := c.Query("until"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid until (expected RFC3339)"})
+			return
+		}
+		filter.Until = &t
+	}
+
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	events, err := models.ListAuditEvents(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
+
+// MyActivity는 로그인된 사용자 본인의 최근 인증 활동 내역을 반환한다.
+func MyActivity(c *gin.Context) {
+	claims, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = l
+	}
+
+	events, err := models.ListAuditEvents(models.AuditEventFilter{UserID: &claims.UserID, Limit: limit})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list activity"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
@@ -26,6 +26,10 @@ type RefreshRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // 비어있으면 모든 기기에서 로그아웃
+}
+
 type TokenResponse struct {
 	AccessToken  string      `json:"access_token"`
 	RefreshToken string      `json:"refresh_token,omitempty"`
@@ -63,12 +67,14 @@ func Register(c *gin.Context) {
 	}
 
 	// 토큰 생성
-	accessToken, refreshToken, err := utils.GenerateTokens(user.ID, user.Email)
+	accessToken, refreshToken, err := utils.GenerateTokens(user.ID, user.Email, c.Request.UserAgent(), c.ClientIP(), false)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tokens"})
 		return
 	}
 
+	utils.LogAuditEvent(&user.ID, "user.register", c.ClientIP(), c.Request.UserAgent(), nil)
+
 	c.JSON(http.StatusCreated, TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -85,10 +91,24 @@ func Login(c *gin.Context) {
 		return
 	}
 
+	// 반복된 실패로 쌓인 지수 백오프 잠금이 남아있으면 비밀번호 확인 없이 거절한다
+	lockedFor, err := utils.LoginLockedFor(req.Email)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if lockedFor > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(lockedFor.Seconds())))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many failed attempts, try again later"})
+		return
+	}
+
 	// 사용자 조회
 	user, err := models.GetUserByEmail(req.Email)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			_ = utils.RecordLoginFailure(req.Email)
+			utils.LogAuditEvent(nil, "login.failure", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"reason": "no_such_user", "email": req.Email})
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 			return
 		}
@@ -99,17 +119,48 @@ func Login(c *gin.Context) {
 	// 비밀번호 확인
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
+		_ = utils.RecordLoginFailure(req.Email)
+		utils.LogAuditEvent(&user.ID, "login.failure", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"reason": "bad_password"})
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
 		return
 	}
+	_ = utils.ClearLoginFailures(req.Email)
+
+	if user.Disabled {
+		utils.LogAuditEvent(&user.ID, "login.failure", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"reason": "account_disabled"})
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account disabled"})
+		return
+	}
+
+	// MFA가 활성화된 계정이면 정식 토큰 대신 mfa_pending 토큰만 내려준다
+	mfaEnabled, err := models.IsMFAEnabled(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Database error"})
+		return
+	}
+	if mfaEnabled {
+		pendingToken, err := utils.GenerateMFAPendingToken(user.ID, user.Email)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate MFA challenge"})
+			return
+		}
+		utils.LogAuditEvent(&user.ID, "login.mfa_challenge", c.ClientIP(), c.Request.UserAgent(), nil)
+		c.JSON(http.StatusOK, gin.H{
+			"mfa_required": true,
+			"mfa_token":    pendingToken,
+		})
+		return
+	}
 
 	// 토큰 생성
-	accessToken, refreshToken, err := utils.GenerateTokens(user.ID, user.Email)
+	accessToken, refreshToken, err := utils.GenerateTokens(user.ID, user.Email, c.Request.UserAgent(), c.ClientIP(), false)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tokens"})
 		return
 	}
 
+	utils.LogAuditEvent(&user.ID, "login.success", c.ClientIP(), c.Request.UserAgent(), nil)
+
 	// 비밀번호 필드 제거
 	user.Password = ""
 
@@ -143,17 +194,22 @@ func Refresh(c *gin.Context) {
 		return
 	}
 
-	// 새로운 액세스 토큰 생성
-	newAccessToken, err := utils.RefreshAccessToken(claims.UserID, req.RefreshToken)
+	// 새로운 access/refresh 토큰 쌍 생성 (refresh token은 매 호출마다 회전된다)
+	// email/MFA 상태는 기존 토큰의 claims를 그대로 이어받는다
+	newAccessToken, newRefreshToken, err := utils.RefreshAccessToken(claims.UserID, claims.Email, claims.MFA, req.RefreshToken)
 	if err != nil {
+		utils.LogAuditEvent(&claims.UserID, "token.refresh_failure", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"reason": err.Error()})
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
 		return
 	}
 
+	utils.LogAuditEvent(&claims.UserID, "token.refresh", c.ClientIP(), c.Request.UserAgent(), nil)
+
 	c.JSON(http.StatusOK, TokenResponse{
-		AccessToken: newAccessToken,
-		TokenType:   "Bearer",
-		ExpiresIn:   900, // 15분
+		AccessToken:  newAccessToken,
+		RefreshToken: newRefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    900, // 15분
 	})
 }
 
@@ -171,13 +227,18 @@ func Logout(c *gin.Context) {
 		return
 	}
 
-	// Refresh token 삭제
-	err = utils.RevokeRefreshToken(claims.UserID)
+	var req LogoutRequest
+	_ = c.ShouldBindJSON(&req) // 본문 없이 호출해도 모든 기기 로그아웃으로 처리
+
+	// Refresh token 삭제 (refresh_token이 주어지면 해당 기기만, 아니면 전체)
+	err = utils.RevokeRefreshToken(claims.UserID, req.RefreshToken)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to logout"})
 		return
 	}
 
+	utils.LogAuditEvent(&claims.UserID, "logout", c.ClientIP(), c.Request.UserAgent(), nil)
+
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
@@ -190,7 +251,7 @@ func Verify(c *gin.Context) {
 
 	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 	claims, err := utils.VerifyToken(tokenString)
-	if err != nil {
+	if err != nil || claims.MFAPending {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 		return
 	}
@@ -201,14 +262,22 @@ func Verify(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
 		return
 	}
+	if user.Disabled {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Account disabled"})
+		return
+	}
 
 	// Envoy에서 사용할 헤더 설정
 	c.Header("X-User-ID", strconv.Itoa(user.ID))
 	c.Header("X-User-Email", user.Email)
+	if len(claims.Roles) > 0 {
+		c.Header("X-User-Roles", strings.Join(claims.Roles, ","))
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"valid":  true,
 		"user_id": user.ID,
 		"email":   user.Email,
+		"roles":   claims.Roles,
 	})
 }
\ No newline at end of file
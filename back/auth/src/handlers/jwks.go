@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"auth-server/src/config"
+	"encoding/base64"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS는 현재 + 이전(회전 유예 기간) 공개키를 RFC 7517 형식으로 노출한다.
+// Envoy 등 다운스트림 서비스가 매 요청마다 인증 서버를 호출하지 않고도
+// 이 엔드포인트로 JWT 서명을 직접 검증할 수 있게 해준다.
+func JWKS(c *gin.Context) {
+	keys := config.AllVerifyingKeys()
+
+	jwks := make([]jwk, 0, len(keys))
+	for _, k := range keys {
+		jwks = append(jwks, jwk{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: "RS256",
+			Kid: k.KID,
+			N:   base64.RawURLEncoding.EncodeToString(k.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.PublicKey.E)).Bytes()),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"keys": jwks})
+}
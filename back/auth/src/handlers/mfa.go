@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"auth-server/src/models"
+	"auth-server/src/utils"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const mfaIssuer = "auth-server"
+
+type MFAConfirmRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFAEnrollRequest는 이미 confirmed된 MFA를 재등록할 때만 필요하다 (둘 중 하나로
+// 재인증한다). 최초 등록이면 비워 둔다.
+type MFAEnrollRequest struct {
+	Code     string `json:"code"`
+	Password string `json:"password"`
+}
+
+type MFAVerifyRequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// EnrollMFA는 로그인된 사용자를 위한 새 TOTP 비밀값을 생성하고, 인증 앱에 바로
+// 등록할 수 있도록 otpauth:// URI와 QR 코드 PNG(base64)를 함께 내려준다.
+// 이 시점에는 아직 미확인 상태이며 mfa/confirm을 거쳐야 로그인에 적용된다.
+func EnrollMFA(c *gin.Context) {
+	claims, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	existing, err := models.GetUserTOTP(claims.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check existing enrollment"})
+		return
+	}
+	if err == nil && existing.ConfirmedAt != nil {
+		// 이미 확인된 MFA는 현재 TOTP 코드나 비밀번호로 재인증해야 덮어쓸 수 있다 -
+		// 그렇지 않으면 탈취된 access token만으로 피해자의 MFA를 조용히 재설정할 수 있다.
+		var req MFAEnrollRequest
+		_ = c.ShouldBindJSON(&req)
+
+		reverified := false
+		if req.Code != "" {
+			if secret, derr := utils.DecryptSecret(existing.Secret); derr == nil && utils.ValidateTOTPCode(secret, req.Code) {
+				reverified = true
+			}
+		}
+		if !reverified && req.Password != "" {
+			if user, uerr := models.GetUserByEmail(claims.Email); uerr == nil {
+				if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)) == nil {
+					reverified = true
+				}
+			}
+		}
+		if !reverified {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Current TOTP code or password required to re-enroll MFA"})
+			return
+		}
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate TOTP secret"})
+		return
+	}
+
+	encryptedSecret, err := utils.EncryptSecret(secret)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encrypt TOTP secret"})
+		return
+	}
+
+	if _, err := models.UpsertUserTOTP(claims.UserID, encryptedSecret); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save TOTP enrollment"})
+		return
+	}
+
+	otpauthURL := utils.TOTPAuthURL(mfaIssuer, claims.Email, secret)
+
+	png, err := qrcode.Encode(otpauthURL, qrcode.Medium, 256)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to render QR code"})
+		return
+	}
+
+	utils.LogAuditEvent(&claims.UserID, "mfa.enroll", c.ClientIP(), c.Request.UserAgent(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret":      secret,
+		"otpauth_url": otpauthURL,
+		"qr_code_png": base64.StdEncoding.EncodeToString(png),
+	})
+}
+
+// ConfirmMFA는 최초 TOTP 코드를 검증해 MFA를 활성화하고, 1회용 복구 코드
+// 10개를 평문으로 한 번만 반환한다 (저장소에는 bcrypt 해시만 남긴다).
+func ConfirmMFA(c *gin.Context) {
+	claims, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req MFAConfirmRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	totp, err := models.GetUserTOTP(claims.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "MFA enrollment not started"})
+		return
+	}
+
+	secret, err := utils.DecryptSecret(totp.Secret)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decrypt TOTP secret"})
+		return
+	}
+
+	if !utils.ValidateTOTPCode(secret, req.Code) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid TOTP code"})
+		return
+	}
+
+	recoveryCodes, hashedCodes, err := generateRecoveryCodes(10)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate recovery codes"})
+		return
+	}
+
+	if err := models.ConfirmUserTOTP(claims.UserID, hashedCodes); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to confirm MFA"})
+		return
+	}
+
+	utils.LogAuditEvent(&claims.UserID, "mfa.enabled", c.ClientIP(), c.Request.UserAgent(), nil)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "MFA enabled",
+		"recovery_codes": recoveryCodes,
+	})
+}
+
+// VerifyMFA는 Login이 발급한 mfa_pending 토큰과 TOTP 코드(또는 복구 코드)를
+// 받아 정식 access/refresh 토큰 쌍으로 교환한다.
+func VerifyMFA(c *gin.Context) {
+	var req MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	claims, err := utils.VerifyToken(req.MFAToken)
+	if err != nil || !claims.MFAPending {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired MFA challenge"})
+		return
+	}
+
+	totp, err := models.GetUserTOTP(claims.UserID)
+	if err != nil || totp.ConfirmedAt == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "MFA not enabled for this account"})
+		return
+	}
+
+	verified := false
+
+	if req.Code != "" {
+		secret, err := utils.DecryptSecret(totp.Secret)
+		if err == nil && utils.ValidateTOTPCode(secret, req.Code) {
+			verified = true
+		}
+	}
+
+	if !verified && req.RecoveryCode != "" {
+		if hash, ok := matchRecoveryCode(totp.RecoveryCodesHashed, req.RecoveryCode); ok {
+			if err := models.ConsumeRecoveryCode(claims.UserID, hash); err == nil {
+				verified = true
+			}
+		}
+	}
+
+	if !verified {
+		utils.LogAuditEvent(&claims.UserID, "mfa.verify_failure", c.ClientIP(), c.Request.UserAgent(), nil)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid MFA code"})
+		return
+	}
+
+	accessToken, refreshToken, err := utils.GenerateTokens(claims.UserID, claims.Email, c.Request.UserAgent(), c.ClientIP(), true)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tokens"})
+		return
+	}
+
+	utils.LogAuditEvent(&claims.UserID, "login.success", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"mfa": true})
+
+	c.JSON(http.StatusOK, TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    900, // 15분
+	})
+}
+
+// DisableMFA는 로그인된 사용자의 TOTP 등록을 제거한다.
+func DisableMFA(c *gin.Context) {
+	claims, ok := requireAuth(c)
+	if !ok {
+		return
+	}
+
+	if err := models.DeleteUserTOTP(claims.UserID); err != nil && err != sql.ErrNoRows {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to disable MFA"})
+		return
+	}
+
+	utils.LogAuditEvent(&claims.UserID, "mfa.disabled", c.ClientIP(), c.Request.UserAgent(), nil)
+
+	c.JSON(http.StatusOK, gin.H{"message": "MFA disabled"})
+}
+
+// requireAuth는 Authorization 헤더의 access token을 검증한다. mfa_pending
+// 토큰(1차 인증만 통과한 상태)은 거부한다 - 그렇지 않으면 비밀번호만 아는
+// 공격자가 mfa_pending 토큰으로 DisableMFA 등을 호출해 2차 인증을 무력화할 수 있다.
+func requireAuth(c *gin.Context) (*utils.Claims, bool) {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+		return nil, false
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	claims, err := utils.VerifyToken(tokenString)
+	if err != nil || claims.MFAPending {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		return nil, false
+	}
+
+	return claims, true
+}
+
+func generateRecoveryCodes(count int) (plain []string, hashed []string, err error) {
+	for i := 0; i < count; i++ {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		plain = append(plain, code)
+		hashed = append(hashed, string(hash))
+	}
+
+	return plain, hashed, nil
+}
+
+func randomRecoveryCode() (string, error) {
+	buf := make([]byte, 5)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x-%x", buf[:2], buf[2:]), nil
+}
+
+func matchRecoveryCode(hashed []string, code string) (string, bool) {
+	for _, h := range hashed {
+		if bcrypt.CompareHashAndPassword([]byte(h), []byte(code)) == nil {
+			return h, true
+		}
+	}
+	return "", false
+}
+
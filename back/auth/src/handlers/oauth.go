@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"auth-server/src/config"
+	"auth-server/src/models"
+	"auth-server/src/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+)
+
+// oauthUserInfo는 프로바이더별 userinfo 응답에서 공통으로 필요한 필드만 뽑아낸다.
+// 구글/제네릭 OIDC는 "sub"를, 깃헙은 숫자 "id"를 주체 식별자로 쓴다.
+type oauthUserInfo struct {
+	Sub           string      `json:"sub"`
+	ID            json.Number `json:"id"`
+	Email         string      `json:"email"`
+	EmailVerified bool        `json:"email_verified"`
+}
+
+// errEmailNotVerified는 프로바이더가 이메일을 검증했다고 보장하지 않는데 그
+// 이메일과 같은 기존 계정이 있을 때 반환된다 (OAuth email confusion 방지).
+var errEmailNotVerified = errors.New("email not verified by provider")
+
+func (u oauthUserInfo) subject() string {
+	if u.Sub != "" {
+		return u.Sub
+	}
+	return u.ID.String()
+}
+
+// OAuthLogin은 authorization-code + PKCE 플로우를 시작한다. 이미 로그인된
+// 사용자(Authorization 헤더 포함)가 호출하면 계정 연결(link) 플로우로 동작한다.
+func OAuthLogin(c *gin.Context) {
+	provider, ok := resolveProvider(c)
+	if !ok {
+		return
+	}
+
+	state := uuid.New().String()
+	verifier := oauth2.GenerateVerifier()
+
+	oauthState := utils.OAuthState{Provider: c.Param("provider"), Verifier: verifier}
+
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		if claims, err := utils.VerifyToken(tokenString); err == nil && !claims.MFAPending {
+			oauthState.LinkUserID = claims.UserID
+		}
+	}
+
+	if err := utils.StoreOAuthState(state, oauthState); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start OAuth flow"})
+		return
+	}
+
+	authURL := provider.Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// OAuthCallback은 authorization code를 교환하고, 사용자 정보를 조회해 계정을
+// 연결하거나 새로 만든 뒤 일반 Login과 동일한 TokenResponse를 내려준다.
+func OAuthCallback(c *gin.Context) {
+	provider, ok := resolveProvider(c)
+	if !ok {
+		return
+	}
+
+	state := c.Query("state")
+	code := c.Query("code")
+	if state == "" || code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing state or code"})
+		return
+	}
+
+	oauthState, err := utils.ConsumeOAuthState(state)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired state"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	token, err := provider.Config.Exchange(ctx, code, oauth2.VerifierOption(oauthState.Verifier))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to exchange code"})
+		return
+	}
+
+	info, err := fetchOAuthUserInfo(ctx, provider, token)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Failed to fetch user info"})
+		return
+	}
+
+	user, err := resolveOAuthUser(oauthState.Provider, info, oauthState.LinkUserID)
+	if errors.Is(err, errEmailNotVerified) {
+		c.JSON(http.StatusConflict, gin.H{"error": "An account with this email already exists; log in and link this provider from your account settings"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to provision user"})
+		return
+	}
+
+	accessToken, refreshToken, err := utils.GenerateTokens(user.ID, user.Email, c.Request.UserAgent(), c.ClientIP(), false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate tokens"})
+		return
+	}
+
+	utils.LogAuditEvent(&user.ID, "login.success", c.ClientIP(), c.Request.UserAgent(), map[string]interface{}{"provider": oauthState.Provider})
+
+	user.Password = ""
+	c.JSON(http.StatusOK, TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    900, // 15분
+		User:         user,
+	})
+}
+
+func resolveProvider(c *gin.Context) (*config.OAuthProvider, bool) {
+	provider, ok := config.OAuthProviders[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Unknown OAuth provider"})
+		return nil, false
+	}
+	return provider, true
+}
+
+func fetchOAuthUserInfo(ctx context.Context, provider *config.OAuthProvider, token *oauth2.Token) (*oauthUserInfo, error) {
+	client := provider.Config.Client(ctx, token)
+	resp, err := client.Get(provider.UserInfoURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo request failed: %d", resp.StatusCode)
+	}
+
+	var info oauthUserInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	if info.subject() == "" || info.Email == "" {
+		return nil, fmt.Errorf("userinfo response missing subject or email")
+	}
+
+	return &info, nil
+}
+
+// resolveOAuthUser는 이미 연결된 identity -> 로그인 중 계정 연결 -> 이메일로
+// 기존 계정 매칭 -> 신규 프로비저닝 순서로 사용자를 찾거나 만든다.
+// 이메일로 기존 계정에 매칭하는 경우, 프로바이더가 email_verified=true를
+// 보장하지 않으면 연결을 거부한다 (그렇지 않으면 임의의 이메일을 자기 것이라고
+// 주장할 수 있는 IdP에 가입하는 것만으로 그 이메일의 기존 로컬 계정을 탈취할 수 있다).
+func resolveOAuthUser(provider string, info *oauthUserInfo, linkUserID int) (*models.User, error) {
+	subject := info.subject()
+
+	if identity, err := models.GetUserIdentity(provider, subject); err == nil {
+		return models.GetUserByID(identity.UserID)
+	}
+
+	if linkUserID != 0 {
+		if _, err := models.CreateUserIdentity(linkUserID, provider, subject, info.Email); err != nil {
+			return nil, err
+		}
+		return models.GetUserByID(linkUserID)
+	}
+
+	user, err := models.GetUserByEmail(info.Email)
+	if err == sql.ErrNoRows {
+		// 임의의 비밀번호 해시로 소셜 전용 계정을 생성한다 (비밀번호 로그인은 쓰이지 않는다)
+		randomPassword, err := bcrypt.GenerateFromPassword([]byte(uuid.New().String()), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		user, err = models.CreateUser(info.Email, string(randomPassword))
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	} else if !info.EmailVerified {
+		return nil, errEmailNotVerified
+	}
+
+	if _, err := models.CreateUserIdentity(user.ID, provider, subject, info.Email); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
@@ -3,6 +3,9 @@ package main
 import (
 	"auth-server/src/config"
 	"auth-server/src/handlers"
+	"auth-server/src/middleware"
+	"auth-server/src/models"
+	"auth-server/src/utils"
 	"log"
 	"os"
 
@@ -18,6 +21,29 @@ func main() {
 	config.InitRedis()
 	defer config.Redis.Close()
 
+	// JWT 서명 키 초기화 (RS256, 회전 지원)
+	if err := config.InitSigningKeys(); err != nil {
+		log.Fatal("Failed to init signing keys:", err)
+	}
+
+	// 환경변수에 설정된 OAuth/OIDC 프로바이더 등록
+	config.InitOAuthProviders()
+
+	// RATELIMIT_* 환경변수로 엔드포인트별 레이트 리밋 설정
+	config.InitRateLimits()
+
+	// BOOTSTRAP_ADMIN_EMAIL 계정이 가입되어 있으면 admin 역할을 부여
+	if email := os.Getenv("BOOTSTRAP_ADMIN_EMAIL"); email != "" {
+		if err := models.BootstrapAdmin(email); err != nil {
+			log.Printf("Failed to bootstrap admin (%s): %v", email, err)
+		}
+	}
+
+	// 감사 로그 비동기 writer 시작 (AUDIT_RETENTION 설정 시 보존 정리 job도 함께 시작)
+	if err := utils.StartAuditWriter(); err != nil {
+		log.Fatal("Failed to start audit writer:", err)
+	}
+
 	log.Println("Database and Redis connections established")
 
 	// Gin 라우터 설정
@@ -36,26 +62,65 @@ func main() {
 		c.Next()
 	})
 
+	// 민감한 엔드포인트에 적용할 레이트 리미터
+	loginLimiter := middleware.Limit("login", config.RateLimits.Login, middleware.LoginKey)
+	refreshLimiter := middleware.Limit("refresh", config.RateLimits.Refresh, middleware.RefreshKey)
+	registerLimiter := middleware.Limit("register", config.RateLimits.Register, middleware.IPKey)
+	verifyLimiter := middleware.Limit("verify", config.RateLimits.Verify, middleware.VerifyUserKey)
+	mfaLimiter := middleware.Limit("mfa", config.RateLimits.MFA, middleware.VerifyUserKey)
+	mfaVerifyLimiter := middleware.Limit("mfa_verify", config.RateLimits.MFA, middleware.MFAVerifyKey)
+
 	// API 라우트
 	api := r.Group("/api/v1")
 	{
 		// 인증 관련 엔드포인트
-		api.POST("/register", handlers.Register)
-		api.POST("/login", handlers.Login)
-		api.POST("/refresh", handlers.Refresh)
+		api.POST("/register", registerLimiter, handlers.Register)
+		api.POST("/login", loginLimiter, handlers.Login)
+		api.POST("/refresh", refreshLimiter, handlers.Refresh)
 		api.POST("/logout", handlers.Logout)
-		api.GET("/verify", handlers.Verify) // Envoy가 호출
-		api.POST("/verify", handlers.Verify) // POST 요청 지원
-		api.PUT("/verify", handlers.Verify) // PUT 요청 지원
-		api.DELETE("/verify", handlers.Verify) // DELETE 요청 지원
-		api.PATCH("/verify", handlers.Verify) // PATCH 요청 지원
-		api.GET("/verify/*path", handlers.Verify) // Envoy가 path_prefix로 호출하는 경우
-		api.POST("/verify/*path", handlers.Verify) // POST path_prefix 지원
-		api.PUT("/verify/*path", handlers.Verify) // PUT path_prefix 지원
-		api.DELETE("/verify/*path", handlers.Verify) // DELETE path_prefix 지원
-		api.PATCH("/verify/*path", handlers.Verify) // PATCH path_prefix 지원
+		api.GET("/sessions", handlers.ListSessions)        // 로그인된 기기 목록
+		api.DELETE("/sessions/:id", handlers.RevokeSession) // 특정 기기 로그아웃
+
+		// MFA(TOTP) 관련 엔드포인트
+		api.POST("/mfa/enroll", mfaLimiter, handlers.EnrollMFA)
+		api.POST("/mfa/confirm", mfaLimiter, handlers.ConfirmMFA)
+		api.POST("/mfa/verify", mfaVerifyLimiter, handlers.VerifyMFA)
+		api.DELETE("/mfa", mfaLimiter, handlers.DisableMFA)
+
+		// 소셜/OIDC 로그인
+		api.GET("/oauth/:provider/login", handlers.OAuthLogin)
+		api.GET("/oauth/:provider/callback", handlers.OAuthCallback)
+
+		// 관리자 전용 엔드포인트
+		admin := api.Group("/admin")
+		admin.Use(middleware.RequireRole("admin"))
+		{
+			admin.GET("/users", handlers.ListUsers)
+			admin.GET("/users/:id", handlers.GetUser)
+			admin.POST("/users/:id/disable", handlers.DisableUser)
+			admin.POST("/users/:id/force-logout", handlers.ForceLogoutUser)
+			admin.POST("/users/:id/roles", handlers.AssignUserRole)
+			admin.GET("/audit", handlers.ListAuditEvents)
+		}
+
+		// 로그인된 사용자 본인의 최근 인증 활동
+		api.GET("/me/activity", handlers.MyActivity)
+
+		api.GET("/verify", verifyLimiter, handlers.Verify) // Envoy가 호출
+		api.POST("/verify", verifyLimiter, handlers.Verify) // POST 요청 지원
+		api.PUT("/verify", verifyLimiter, handlers.Verify) // PUT 요청 지원
+		api.DELETE("/verify", verifyLimiter, handlers.Verify) // DELETE 요청 지원
+		api.PATCH("/verify", verifyLimiter, handlers.Verify) // PATCH 요청 지원
+		api.GET("/verify/*path", verifyLimiter, handlers.Verify) // Envoy가 path_prefix로 호출하는 경우
+		api.POST("/verify/*path", verifyLimiter, handlers.Verify) // POST path_prefix 지원
+		api.PUT("/verify/*path", verifyLimiter, handlers.Verify) // PUT path_prefix 지원
+		api.DELETE("/verify/*path", verifyLimiter, handlers.Verify) // DELETE path_prefix 지원
+		api.PATCH("/verify/*path", verifyLimiter, handlers.Verify) // PATCH path_prefix 지원
 	}
 
+	// JWKS 엔드포인트 (Envoy 등이 JWT 서명을 직접 검증할 수 있도록 공개키 노출)
+	r.GET("/.well-known/jwks.json", handlers.JWKS)
+
 	// 헬스체크 엔드포인트
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
@@ -73,7 +138,20 @@ func main() {
 	log.Println("  POST /api/v1/login - User login")
 	log.Println("  POST /api/v1/refresh - Refresh access token")
 	log.Println("  POST /api/v1/logout - User logout")
+	log.Println("  GET  /api/v1/sessions - List active sessions")
+	log.Println("  DELETE /api/v1/sessions/:id - Revoke a session")
+	log.Println("  POST /api/v1/mfa/enroll - Start TOTP enrollment")
+	log.Println("  POST /api/v1/mfa/confirm - Confirm TOTP enrollment")
+	log.Println("  POST /api/v1/mfa/verify - Verify TOTP during login")
+	log.Println("  DELETE /api/v1/mfa - Disable MFA")
+	log.Println("  GET  /api/v1/oauth/:provider/login - Start social login")
+	log.Println("  GET  /api/v1/oauth/:provider/callback - Social login callback")
+	log.Println("  GET  /api/v1/admin/users - List users (admin)")
+	log.Println("  POST /api/v1/admin/users/:id/roles - Assign a role (admin)")
+	log.Println("  GET  /api/v1/admin/audit - List audit events (admin)")
+	log.Println("  GET  /api/v1/me/activity - Own recent auth activity")
 	log.Println("  GET  /api/v1/verify - Token verification (for Envoy)")
+	log.Println("  GET  /.well-known/jwks.json - JWKS public keys")
 	log.Println("  GET  /health - Health check")
 	
 	r.Run(":" + port)
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"auth-server/src/utils"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole은 access token의 roles 클레임에 주어진 role이 포함된 경우에만
+// 통과시킨다. GenerateTokens가 로그인 시점에 DB에서 조회한 역할을 JWT에 실어
+// 보내므로, 요청마다 DB를 다시 조회하지 않고 권한을 검사할 수 있다.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			return
+		}
+
+		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+		claims, err := utils.VerifyToken(tokenString)
+		if err != nil || claims.MFAPending {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			return
+		}
+
+		for _, r := range claims.Roles {
+			if r == role {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	}
+}
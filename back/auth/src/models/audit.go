@@ -0,0 +1,119 @@
+package models
+
+import (
+	"auth-server/src/config"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// AuditEvent는 인증 관련 동작 하나를 기록한다. UserID는 존재하지 않는 이메일로의
+// 로그인 실패 등 사용자를 특정할 수 없는 이벤트에서는 nil일 수 있다.
+type AuditEvent struct {
+	ID        int             `json:"id"`
+	UserID    *int            `json:"user_id"`
+	EventType string          `json:"event_type"`
+	IP        string          `json:"ip"`
+	UserAgent string          `json:"user_agent"`
+	Metadata  json.RawMessage `json:"metadata,omitempty"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func InitAuditEventTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS audit_events (
+		id SERIAL PRIMARY KEY,
+		user_id INTEGER REFERENCES users(id),
+		event_type VARCHAR(50) NOT NULL,
+		ip VARCHAR(64) NOT NULL,
+		user_agent TEXT NOT NULL,
+		metadata JSONB NOT NULL DEFAULT '{}',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+	CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events (user_id);
+	CREATE INDEX IF NOT EXISTS idx_audit_events_created_at ON audit_events (created_at)`
+
+	_, err := config.DB.Exec(query)
+	return err
+}
+
+// InsertAuditEvent는 이벤트 한 건을 저장한다. utils의 비동기 writer에서 호출되므로
+// 요청 처리 경로를 막지 않는다.
+func InsertAuditEvent(userID *int, eventType, ip, userAgent string, metadata json.RawMessage) error {
+	if metadata == nil {
+		metadata = json.RawMessage("{}")
+	}
+
+	query := `
+	INSERT INTO audit_events (user_id, event_type, ip, user_agent, metadata)
+	VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := config.DB.Exec(query, userID, eventType, ip, userAgent, metadata)
+	return err
+}
+
+// AuditEventFilter는 GET /api/v1/admin/audit 조회 조건이다. 값이 비어있으면
+// 해당 조건은 적용하지 않는다.
+type AuditEventFilter struct {
+	UserID    *int
+	EventType string
+	Since     *time.Time
+	Until     *time.Time
+	Limit     int
+}
+
+// ListAuditEvents는 필터 조건에 맞는 감사 로그를 최신순으로 반환한다.
+func ListAuditEvents(filter AuditEventFilter) ([]AuditEvent, error) {
+	query := `SELECT id, user_id, event_type, ip, user_agent, metadata, created_at FROM audit_events WHERE 1=1`
+	var args []interface{}
+
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		query += " AND user_id = $" + strconv.Itoa(len(args))
+	}
+	if filter.EventType != "" {
+		args = append(args, filter.EventType)
+		query += " AND event_type = $" + strconv.Itoa(len(args))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		query += " AND created_at >= $" + strconv.Itoa(len(args))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		query += " AND created_at <= $" + strconv.Itoa(len(args))
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	args = append(args, limit)
+	query += " ORDER BY created_at DESC LIMIT $" + strconv.Itoa(len(args))
+
+	rows, err := config.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []AuditEvent
+	for rows.Next() {
+		var e AuditEvent
+		if err := rows.Scan(&e.ID, &e.UserID, &e.EventType, &e.IP, &e.UserAgent, &e.Metadata, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// DeleteAuditEventsBefore는 보존 기간이 지난 감사 로그를 삭제한다 (retention job용).
+func DeleteAuditEventsBefore(cutoff time.Time) (int64, error) {
+	result, err := config.DB.Exec(`DELETE FROM audit_events WHERE created_at < $1`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
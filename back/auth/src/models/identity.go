@@ -0,0 +1,63 @@
+package models
+
+import (
+	"auth-server/src/config"
+	"time"
+)
+
+// UserIdentity는 소셜/OIDC 로그인으로 연결된 외부 계정 하나를 나타낸다.
+// 같은 사용자가 여러 프로바이더를 연결할 수 있으므로 (provider, subject)가
+// 유니크 키이고 user_id는 users 테이블을 가리킨다.
+type UserIdentity struct {
+	UserID   int       `json:"user_id"`
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	Email    string    `json:"email"`
+	LinkedAt time.Time `json:"linked_at"`
+}
+
+func InitUserIdentityTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS user_identities (
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		provider VARCHAR(50) NOT NULL,
+		subject VARCHAR(255) NOT NULL,
+		email VARCHAR(255) NOT NULL,
+		linked_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (provider, subject)
+	)`
+
+	_, err := config.DB.Exec(query)
+	return err
+}
+
+func CreateUserIdentity(userID int, provider, subject, email string) (*UserIdentity, error) {
+	query := `
+	INSERT INTO user_identities (user_id, provider, subject, email)
+	VALUES ($1, $2, $3, $4)
+	RETURNING user_id, provider, subject, email, linked_at`
+
+	identity := &UserIdentity{}
+	err := config.DB.QueryRow(query, userID, provider, subject, email).Scan(
+		&identity.UserID, &identity.Provider, &identity.Subject, &identity.Email, &identity.LinkedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
+
+func GetUserIdentity(provider, subject string) (*UserIdentity, error) {
+	query := `SELECT user_id, provider, subject, email, linked_at FROM user_identities WHERE provider = $1 AND subject = $2`
+
+	identity := &UserIdentity{}
+	err := config.DB.QueryRow(query, provider, subject).Scan(
+		&identity.UserID, &identity.Provider, &identity.Subject, &identity.Email, &identity.LinkedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"auth-server/src/config"
+	"database/sql"
+)
+
+type Role struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func InitRoleTables() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS roles (
+		id SERIAL PRIMARY KEY,
+		name VARCHAR(50) UNIQUE NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS user_roles (
+		user_id INTEGER NOT NULL REFERENCES users(id),
+		role_id INTEGER NOT NULL REFERENCES roles(id),
+		PRIMARY KEY (user_id, role_id)
+	)`
+
+	_, err := config.DB.Exec(query)
+	return err
+}
+
+func getOrCreateRole(name string) (*Role, error) {
+	query := `
+	INSERT INTO roles (name) VALUES ($1)
+	ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+	RETURNING id, name`
+
+	role := &Role{}
+	err := config.DB.QueryRow(query, name).Scan(&role.ID, &role.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// AssignRole은 사용자에게 역할을 부여한다. 역할이 없으면 새로 만든다.
+func AssignRole(userID int, roleName string) error {
+	role, err := getOrCreateRole(roleName)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	_, err = config.DB.Exec(query, userID, role.ID)
+	return err
+}
+
+// GetUserRoles는 GenerateTokens가 JWT의 roles 클레임에 실을 역할 이름 목록을 조회한다.
+func GetUserRoles(userID int) ([]string, error) {
+	query := `SELECT r.name FROM roles r JOIN user_roles ur ON ur.role_id = r.id WHERE ur.user_id = $1`
+
+	rows, err := config.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		roles = append(roles, name)
+	}
+
+	return roles, rows.Err()
+}
+
+// BootstrapAdmin은 BOOTSTRAP_ADMIN_EMAIL에 해당하는 계정이 이미 가입되어 있으면
+// admin 역할을 부여한다. 아직 가입 전이면 조용히 건너뛴다 (가입 후 재시작 시 부여된다).
+func BootstrapAdmin(email string) error {
+	user, err := GetUserByEmail(email)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return AssignRole(user.ID, "admin")
+}
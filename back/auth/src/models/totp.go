@@ -0,0 +1,98 @@
+package models
+
+import (
+	"auth-server/src/config"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// UserTOTP는 사용자 1명당 최대 1개의 TOTP 등록 상태를 담는다.
+// Secret은 평문이 아니라 utils.EncryptSecret으로 암호화된 값이 저장된다.
+type UserTOTP struct {
+	UserID              int        `json:"user_id"`
+	Secret              string     `json:"-"`
+	ConfirmedAt         *time.Time `json:"confirmed_at,omitempty"`
+	RecoveryCodesHashed []string   `json:"-"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+func InitUserTOTPTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS user_totp (
+		user_id INTEGER PRIMARY KEY REFERENCES users(id),
+		secret VARCHAR(255) NOT NULL,
+		confirmed_at TIMESTAMP,
+		recovery_codes_hashed TEXT[] NOT NULL DEFAULT '{}',
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`
+
+	_, err := config.DB.Exec(query)
+	return err
+}
+
+// UpsertUserTOTP는 (재)등록 시 호출된다. 기존 등록이 있었다면 확인 전 상태로 되돌린다.
+func UpsertUserTOTP(userID int, encryptedSecret string) (*UserTOTP, error) {
+	query := `
+	INSERT INTO user_totp (user_id, secret)
+	VALUES ($1, $2)
+	ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, confirmed_at = NULL, recovery_codes_hashed = '{}'
+	RETURNING user_id, secret, confirmed_at, recovery_codes_hashed, created_at`
+
+	t := &UserTOTP{}
+	err := config.DB.QueryRow(query, userID, encryptedSecret).Scan(
+		&t.UserID, &t.Secret, &t.ConfirmedAt, pq.Array(&t.RecoveryCodesHashed), &t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func GetUserTOTP(userID int) (*UserTOTP, error) {
+	query := `SELECT user_id, secret, confirmed_at, recovery_codes_hashed, created_at FROM user_totp WHERE user_id = $1`
+
+	t := &UserTOTP{}
+	err := config.DB.QueryRow(query, userID).Scan(
+		&t.UserID, &t.Secret, &t.ConfirmedAt, pq.Array(&t.RecoveryCodesHashed), &t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// ConfirmUserTOTP는 최초 확인 시점에 호출되어 TOTP를 활성화하고 복구 코드를 저장한다.
+func ConfirmUserTOTP(userID int, recoveryCodesHashed []string) error {
+	query := `UPDATE user_totp SET confirmed_at = CURRENT_TIMESTAMP, recovery_codes_hashed = $2 WHERE user_id = $1`
+	_, err := config.DB.Exec(query, userID, pq.Array(recoveryCodesHashed))
+	return err
+}
+
+// ConsumeRecoveryCode는 recovery_codes_hashed에서 사용된 해시를 제거해 1회용으로 만든다.
+func ConsumeRecoveryCode(userID int, usedHash string) error {
+	query := `UPDATE user_totp SET recovery_codes_hashed = array_remove(recovery_codes_hashed, $2) WHERE user_id = $1`
+	_, err := config.DB.Exec(query, userID, usedHash)
+	return err
+}
+
+func DeleteUserTOTP(userID int) error {
+	query := `DELETE FROM user_totp WHERE user_id = $1`
+	_, err := config.DB.Exec(query, userID)
+	return err
+}
+
+// IsMFAEnabled은 사용자가 TOTP 등록을 완료(confirmed)했는지 여부를 반환한다.
+func IsMFAEnabled(userID int) (bool, error) {
+	t, err := GetUserTOTP(userID)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return t.ConfirmedAt != nil, nil
+}
@@ -9,6 +9,7 @@ type User struct {
 	ID        int       `json:"id"`
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` // 응답에서 제외
+	Disabled  bool      `json:"disabled"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -19,6 +20,7 @@ func InitUserTable() error {
 		id SERIAL PRIMARY KEY,
 		email VARCHAR(255) UNIQUE NOT NULL,
 		password VARCHAR(255) NOT NULL,
+		disabled BOOLEAN NOT NULL DEFAULT FALSE,
 		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 	)`
@@ -29,13 +31,13 @@ func InitUserTable() error {
 
 func CreateUser(email, hashedPassword string) (*User, error) {
 	query := `
-	INSERT INTO users (email, password) 
-	VALUES ($1, $2) 
-	RETURNING id, email, created_at, updated_at`
+	INSERT INTO users (email, password)
+	VALUES ($1, $2)
+	RETURNING id, email, disabled, created_at, updated_at`
 
 	user := &User{}
 	err := config.DB.QueryRow(query, email, hashedPassword).Scan(
-		&user.ID, &user.Email, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Disabled, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -45,11 +47,11 @@ func CreateUser(email, hashedPassword string) (*User, error) {
 }
 
 func GetUserByEmail(email string) (*User, error) {
-	query := `SELECT id, email, password, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, email, password, disabled, created_at, updated_at FROM users WHERE email = $1`
 
 	user := &User{}
 	err := config.DB.QueryRow(query, email).Scan(
-		&user.ID, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Password, &user.Disabled, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -59,15 +61,44 @@ func GetUserByEmail(email string) (*User, error) {
 }
 
 func GetUserByID(id int) (*User, error) {
-	query := `SELECT id, email, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, email, disabled, created_at, updated_at FROM users WHERE id = $1`
 
 	user := &User{}
 	err := config.DB.QueryRow(query, id).Scan(
-		&user.ID, &user.Email, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.Email, &user.Disabled, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	return user, nil
+}
+
+// ListUsers는 관리자용 사용자 목록을 최신 가입순으로 반환한다.
+func ListUsers() ([]User, error) {
+	query := `SELECT id, email, disabled, created_at, updated_at FROM users ORDER BY id DESC`
+
+	rows, err := config.DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Email, &user.Disabled, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// SetUserDisabled는 관리자가 계정을 정지/복구할 때 쓰인다.
+func SetUserDisabled(id int, disabled bool) error {
+	query := `UPDATE users SET disabled = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	_, err := config.DB.Exec(query, id, disabled)
+	return err
 }
\ No newline at end of file
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"time"
+
+	"auth-server/src/models"
+)
+
+// auditEvent는 채널로 넘겨지는 미기록 이벤트 한 건이다.
+type auditEvent struct {
+	UserID    *int
+	EventType string
+	IP        string
+	UserAgent string
+	Metadata  map[string]interface{}
+}
+
+// auditQueue는 요청 경로를 막지 않도록 DB insert를 비동기로 넘기는 버퍼다.
+// 큐가 가득 차면(워커가 DB 지연 등으로 밀리는 경우) 이벤트는 버려지고 로그만 남긴다 -
+// 감사 로그 한 건 유실이 로그인/회원가입 요청 실패보다는 낫다는 판단.
+var auditQueue = make(chan auditEvent, 1000)
+
+// StartAuditWriter는 audit_events에 비동기로 기록하는 워커 고루틴을 시작하고,
+// AUDIT_RETENTION이 설정되어 있으면 오래된 로그를 정리하는 job도 함께 시작한다.
+// main()에서 한 번만 호출하면 된다.
+func StartAuditWriter() error {
+	go auditWriterLoop()
+
+	retentionSpec := os.Getenv("AUDIT_RETENTION")
+	if retentionSpec == "" {
+		return nil
+	}
+
+	retention, err := time.ParseDuration(retentionSpec)
+	if err != nil {
+		return err
+	}
+
+	interval := 24 * time.Hour
+	if i := os.Getenv("AUDIT_RETENTION_INTERVAL"); i != "" {
+		id, err := time.ParseDuration(i)
+		if err != nil {
+			return err
+		}
+		interval = id
+	}
+
+	go rotateAuditEvents(retention, interval)
+	log.Printf("audit: retention enabled (keep=%s, interval=%s)", retention, interval)
+	return nil
+}
+
+func rotateAuditEvents(retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cutoff := time.Now().Add(-retention)
+		deleted, err := models.DeleteAuditEventsBefore(cutoff)
+		if err != nil {
+			log.Printf("audit retention: failed to purge events before %s: %v", cutoff, err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("audit retention: purged %d events older than %s", deleted, cutoff)
+		}
+	}
+}
+
+func auditWriterLoop() {
+	for event := range auditQueue {
+		var metadataJSON json.RawMessage
+		if event.Metadata != nil {
+			data, err := json.Marshal(event.Metadata)
+			if err != nil {
+				log.Printf("audit: failed to marshal metadata for %s: %v", event.EventType, err)
+			} else {
+				metadataJSON = data
+			}
+		}
+
+		if err := models.InsertAuditEvent(event.UserID, event.EventType, event.IP, event.UserAgent, metadataJSON); err != nil {
+			log.Printf("audit: failed to write event %s: %v", event.EventType, err)
+		}
+	}
+}
+
+// LogAuditEvent는 인증 관련 이벤트를 큐에 넣는다. 호출자는 결과를 기다리지 않는다 -
+// Register/Login/Refresh 등 요청 처리 경로에서 에러 처리 없이 호출하면 된다.
+func LogAuditEvent(userID *int, eventType, ip, userAgent string, metadata map[string]interface{}) {
+	select {
+	case auditQueue <- auditEvent{UserID: userID, EventType: eventType, IP: ip, UserAgent: userAgent, Metadata: metadata}:
+	default:
+		log.Printf("audit: queue full, dropping event %s", eventType)
+	}
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"auth-server/src/config"
+)
+
+const loginFailureThreshold = 5
+
+// loginFailureWindow는 실패 횟수를 세는 고정 관측 구간이다. 실패할 때마다 TTL을
+// 다시 거는 게 아니라 첫 실패 때만 걸어야, 공격자가 TTL보다 느리게 시도해도
+// 카운터가 계속 쌓인다.
+const loginFailureWindow = 15 * time.Minute
+const maxLoginBackoff = time.Hour
+
+func loginFailureKey(email string) string {
+	return fmt.Sprintf("login_fail:%s", email)
+}
+
+func loginLockKey(email string) string {
+	return fmt.Sprintf("login_lock:%s", email)
+}
+
+// RecordLoginFailure는 계정별 실패 횟수를 1 늘린다 (카운터는 loginFailureWindow
+// 동안 누적된다). 실패 횟수가 임계값에 도달하면 (실패 횟수 - 임계값 + 1)의
+// 제곱(초, 최대 1시간)만큼 별도의 잠금 키를 걸어 크리덴셜 스터핑을 늦춘다.
+func RecordLoginFailure(email string) error {
+	ctx := context.Background()
+	key := loginFailureKey(email)
+
+	count, err := config.Redis.Incr(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		if err := config.Redis.Expire(ctx, key, loginFailureWindow).Err(); err != nil {
+			return err
+		}
+	}
+
+	if count < loginFailureThreshold {
+		return nil
+	}
+
+	over := count - loginFailureThreshold + 1
+	backoff := time.Duration(over*over) * time.Second
+	if backoff > maxLoginBackoff {
+		backoff = maxLoginBackoff
+	}
+
+	return config.Redis.Set(ctx, loginLockKey(email), "1", backoff).Err()
+}
+
+// ClearLoginFailures는 로그인 성공 시 실패 카운터와 잠금을 초기화한다.
+func ClearLoginFailures(email string) error {
+	ctx := context.Background()
+	return config.Redis.Del(ctx, loginFailureKey(email), loginLockKey(email)).Err()
+}
+
+// LoginLockedFor는 잠금이 걸려 있다면 남은 잠금 시간을 반환한다.
+func LoginLockedFor(email string) (time.Duration, error) {
+	ctx := context.Background()
+
+	ttl, err := config.Redis.TTL(ctx, loginLockKey(email)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+)
+
+// mfaEncryptionKey는 MFA_ENCRYPTION_KEY(base64로 인코딩된 32바이트 키)에서
+// AES-256-GCM 키를 읽어온다. TOTP 비밀값을 저장소에 평문으로 두지 않기 위해 쓰인다.
+func mfaEncryptionKey() ([]byte, error) {
+	encoded := os.Getenv("MFA_ENCRYPTION_KEY")
+	if encoded == "" {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY not set")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MFA_ENCRYPTION_KEY: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("MFA_ENCRYPTION_KEY must decode to 32 bytes")
+	}
+
+	return key, nil
+}
+
+// EncryptSecret은 평문을 AES-256-GCM으로 암호화해 base64 문자열로 반환한다.
+func EncryptSecret(plaintext string) (string, error) {
+	key, err := mfaEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// DecryptSecret은 EncryptSecret으로 암호화된 문자열을 복호화한다.
+func DecryptSecret(encoded string) (string, error) {
+	key, err := mfaEncryptionKey()
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
@@ -1,34 +1,40 @@
 package utils
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"strconv"
 	"time"
 
 	"auth-server/src/config"
+	"auth-server/src/models"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 )
 
 type Claims struct {
-	UserID int    `json:"user_id"`
-	Email  string `json:"email"`
+	UserID     int      `json:"user_id"`
+	Email      string   `json:"email"`
+	Roles      []string `json:"roles,omitempty"`
+	MFA        bool     `json:"mfa,omitempty"`         // step-up MFA를 통과한 세션인지
+	MFAPending bool     `json:"mfa_pending,omitempty"` // mfa/verify 전용 단기 토큰인지
 	jwt.RegisteredClaims
 }
 
-func GenerateTokens(userID int, email string) (string, string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", "", fmt.Errorf("JWT_SECRET not set")
+// signAccessToken은 현재 서명 키로 RS256 access token을 발급한다.
+// Header에 kid를 실어 보내므로 Envoy 등 다운스트림은 JWKS에서 맞는 공개키를
+// 찾아 인증 서버를 호출하지 않고도 토큰을 검증할 수 있다. roles도 함께 실어
+// 보내므로 다운스트림은 RequireRole 검사를 위해 DB를 매번 조회할 필요가 없다.
+func signAccessToken(userID int, email string, roles []string, mfaVerified bool) (string, error) {
+	key := config.CurrentSigningKey()
+	if key == nil {
+		return "", fmt.Errorf("signing key not initialized")
 	}
 
-	// Access Token (15분)
-	accessClaims := Claims{
+	claims := Claims{
 		UserID: userID,
 		Email:  email,
+		Roles:  roles,
+		MFA:    mfaVerified,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -36,18 +42,24 @@ func GenerateTokens(userID int, email string) (string, string, error) {
 		},
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString([]byte(secret))
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
+}
+
+func GenerateTokens(userID int, email, userAgent, ip string, mfaVerified bool) (string, string, error) {
+	roles, err := models.GetUserRoles(userID)
 	if err != nil {
 		return "", "", err
 	}
 
-	// Refresh Token (180일) - Redis에 저장할 UUID
-	refreshToken := uuid.New().String()
-	refreshKey := fmt.Sprintf("refresh:%d", userID)
+	accessTokenString, err := signAccessToken(userID, email, roles, mfaVerified)
+	if err != nil {
+		return "", "", err
+	}
 
-	ctx := context.Background()
-	err = config.Redis.Set(ctx, refreshKey, refreshToken, 180*24*time.Hour).Err()
+	// Refresh Token: 기기(세션)별로 새 family를 시작한다
+	refreshToken, err := createRefreshSession(userID, userAgent, ip)
 	if err != nil {
 		return "", "", err
 	}
@@ -55,17 +67,48 @@ func GenerateTokens(userID int, email string) (string, string, error) {
 	return accessTokenString, refreshToken, nil
 }
 
-func VerifyToken(tokenString string) (*Claims, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, fmt.Errorf("JWT_SECRET not set")
+// GenerateMFAPendingToken은 MFA가 활성화된 계정의 1차 인증 성공 시 발급되는
+// 2분짜리 단기 토큰이다. mfa/verify에서 TOTP 코드를 확인한 뒤에만 정식
+// access/refresh 토큰 쌍으로 교환된다.
+func GenerateMFAPendingToken(userID int, email string) (string, error) {
+	key := config.CurrentSigningKey()
+	if key == nil {
+		return "", fmt.Errorf("signing key not initialized")
+	}
+
+	claims := Claims{
+		UserID:     userID,
+		Email:      email,
+		MFAPending: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(2 * time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   strconv.Itoa(userID),
+		},
 	}
 
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.KID
+	return token.SignedString(key.PrivateKey)
+}
+
+func VerifyToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(secret), nil
+
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, fmt.Errorf("token missing kid header")
+		}
+
+		key := config.SigningKeyByKID(kid)
+		if key == nil {
+			return nil, fmt.Errorf("unknown signing key: %s", kid)
+		}
+
+		return key.PublicKey, nil
 	})
 
 	if err != nil {
@@ -77,44 +120,4 @@ func VerifyToken(tokenString string) (*Claims, error) {
 	}
 
 	return nil, fmt.Errorf("invalid token")
-}
-
-func RefreshAccessToken(userID int, refreshToken string) (string, error) {
-	ctx := context.Background()
-	refreshKey := fmt.Sprintf("refresh:%d", userID)
-
-	// Redis에서 저장된 refresh token 확인
-	storedToken, err := config.Redis.Get(ctx, refreshKey).Result()
-	if err != nil {
-		return "", fmt.Errorf("refresh token not found")
-	}
-
-	if storedToken != refreshToken {
-		return "", fmt.Errorf("invalid refresh token")
-	}
-
-	// 새로운 access token 생성 (사용자 정보 조회 필요)
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", fmt.Errorf("JWT_SECRET not set")
-	}
-
-	// 간단히 userID만으로 새 토큰 생성 (실제로는 DB에서 사용자 정보 조회)
-	claims := Claims{
-		UserID: userID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(15 * time.Minute)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			Subject:   strconv.Itoa(userID),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
-}
-
-func RevokeRefreshToken(userID int) error {
-	ctx := context.Background()
-	refreshKey := fmt.Sprintf("refresh:%d", userID)
-	return config.Redis.Del(ctx, refreshKey).Err()
 }
\ No newline at end of file
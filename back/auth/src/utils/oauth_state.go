@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"auth-server/src/config"
+)
+
+const oauthStateTTL = 10 * time.Minute
+
+// OAuthState는 /oauth/:provider/login에서 생성되어 Redis에 저장되고,
+// 콜백에서 한 번만 소비되는 CSRF 방지용 상태값이다. LinkUserID가 채워져
+// 있으면 이미 로그인된 사용자의 계정 연결(link) 요청이라는 뜻이다.
+type OAuthState struct {
+	Provider   string `json:"provider"`
+	Verifier   string `json:"verifier"`
+	LinkUserID int    `json:"link_user_id,omitempty"`
+}
+
+func oauthStateKey(state string) string {
+	return fmt.Sprintf("oauth_state:%s", state)
+}
+
+func StoreOAuthState(state string, s OAuthState) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return config.Redis.Set(ctx, oauthStateKey(state), data, oauthStateTTL).Err()
+}
+
+// ConsumeOAuthState는 상태값을 조회하고 즉시 삭제한다 (재사용 방지).
+func ConsumeOAuthState(state string) (*OAuthState, error) {
+	ctx := context.Background()
+	key := oauthStateKey(state)
+
+	data, err := config.Redis.Get(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("invalid or expired state")
+	}
+	config.Redis.Del(ctx, key)
+
+	var s OAuthState
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
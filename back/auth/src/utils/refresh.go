@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"auth-server/src/config"
+	"auth-server/src/models"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const refreshTokenTTL = 180 * 24 * time.Hour
+
+// rotateRefreshScript는 저장된 세션의 hash가 여전히 기대한 값일 때만 새
+// 세션으로 교체한다 (Redis에서 compare-and-set을 원자적으로 수행). Go에서
+// GET 후 비교하고 따로 SET하면, 같은 refresh token으로 동시에 들어온 두 요청이
+// 둘 다 hash 비교를 통과한 뒤 경합 없이 서로를 덮어쓸 수 있어 회전 보장이 깨진다.
+var rotateRefreshScript = redis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if not data then
+	return 'missing'
+end
+
+local session = cjson.decode(data)
+if session.hash ~= ARGV[1] then
+	return 'mismatch'
+end
+
+redis.call('SET', KEYS[1], ARGV[2], 'EX', ARGV[3])
+return 'ok'
+`)
+
+// RefreshSession은 기기(세션) 하나에 대응하는 refresh token 상태를 나타낸다.
+// Redis 키는 refresh:{userID}:{sessionID} 이며, 로그인할 때마다 새 세션이
+// 시작되고 /refresh 호출마다 Hash가 회전한다.
+type RefreshSession struct {
+	Hash      string    `json:"hash"`
+	FamilyID  string    `json:"family_id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+}
+
+// RefreshSessionView는 GET /api/v1/sessions 응답에 노출되는 필드만 담는다.
+type RefreshSessionView struct {
+	ID        string    `json:"id"`
+	IssuedAt  time.Time `json:"issued_at"`
+	UserAgent string    `json:"user_agent"`
+	IP        string    `json:"ip"`
+}
+
+func refreshSessionKey(userID int, sessionID string) string {
+	return fmt.Sprintf("refresh:%d:%s", userID, sessionID)
+}
+
+func hashRefreshSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// 클라이언트에 내려주는 refresh token은 "{sessionID}.{secret}" 형태의 opaque
+// 문자열이다. sessionID로 Redis 키를 찾고, secret의 해시로 재사용 여부를 판별한다.
+func parseRefreshToken(refreshToken string) (sessionID, secret string, ok bool) {
+	parts := strings.SplitN(refreshToken, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func storeRefreshSession(userID int, sessionID string, session RefreshSession) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return config.Redis.Set(ctx, refreshSessionKey(userID, sessionID), data, refreshTokenTTL).Err()
+}
+
+// createRefreshSession은 새 기기(세션)에 대한 refresh token family를 시작한다.
+func createRefreshSession(userID int, userAgent, ip string) (string, error) {
+	sessionID := uuid.New().String()
+	secret := uuid.New().String()
+
+	session := RefreshSession{
+		Hash:      hashRefreshSecret(secret),
+		FamilyID:  sessionID, // 세션이 시작될 때의 ID를 family의 기준으로 삼는다
+		IssuedAt:  time.Now(),
+		UserAgent: userAgent,
+		IP:        ip,
+	}
+
+	if err := storeRefreshSession(userID, sessionID, session); err != nil {
+		return "", err
+	}
+
+	return sessionID + "." + secret, nil
+}
+
+// RefreshAccessToken은 refresh token을 검증하고, 유효하면 access token과
+// 회전된 새 refresh token을 함께 발급한다. 이미 소비된(회전되어 사라진) 토큰이
+// 재사용되면 탈취로 간주하여 해당 family 전체 세션을 강제 로그아웃시킨다.
+// email과 mfaVerified는 호출자가 검증한 기존 access token의 claims에서 그대로
+// 넘겨받는다 - 새로 조회하지 않으면 MFA 통과 상태가 refresh 때마다 풀려버린다.
+func RefreshAccessToken(userID int, email string, mfaVerified bool, refreshToken string) (string, string, error) {
+	sessionID, secret, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return "", "", fmt.Errorf("malformed refresh token")
+	}
+
+	ctx := context.Background()
+	key := refreshSessionKey(userID, sessionID)
+
+	data, err := config.Redis.Get(ctx, key).Result()
+	if err != nil {
+		return "", "", fmt.Errorf("refresh token not found")
+	}
+
+	var session RefreshSession
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return "", "", fmt.Errorf("corrupt refresh session")
+	}
+
+	expectedHash := hashRefreshSecret(secret)
+	if session.Hash != expectedHash {
+		revokeFamily(userID, session.FamilyID)
+		return "", "", fmt.Errorf("refresh token reuse detected, session family revoked")
+	}
+
+	// 새로운 access token 생성 (roles는 DB 기준 최신 값을 다시 반영한다)
+	roles, err := models.GetUserRoles(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessToken, err := signAccessToken(userID, email, roles, mfaVerified)
+	if err != nil {
+		return "", "", err
+	}
+
+	newSecret := uuid.New().String()
+	newSession := session
+	newSession.Hash = hashRefreshSecret(newSecret)
+	newSession.IssuedAt = time.Now()
+
+	newData, err := json.Marshal(newSession)
+	if err != nil {
+		return "", "", err
+	}
+
+	status, err := rotateRefreshScript.Run(ctx, config.Redis, []string{key}, expectedHash, newData, int(refreshTokenTTL.Seconds())).Text()
+	if err != nil {
+		return "", "", err
+	}
+	if status != "ok" {
+		// 스크립트 실행 사이에 다른 요청이 먼저 회전시킨 경우 - 동시에 들어온
+		// 재사용 시도와 동일하게 취급한다.
+		revokeFamily(userID, session.FamilyID)
+		return "", "", fmt.Errorf("refresh token reuse detected, session family revoked")
+	}
+
+	return accessToken, sessionID + "." + newSecret, nil
+}
+
+// RevokeRefreshToken은 refreshToken이 주어지면 해당 세션만, 비어 있으면
+// 사용자의 모든 세션을 무효화한다 (예: 로그아웃 시 refresh token 미전달).
+func RevokeRefreshToken(userID int, refreshToken string) error {
+	if refreshToken == "" {
+		return revokeAllSessions(userID)
+	}
+
+	sessionID, _, ok := parseRefreshToken(refreshToken)
+	if !ok {
+		return fmt.Errorf("malformed refresh token")
+	}
+
+	ctx := context.Background()
+	return config.Redis.Del(ctx, refreshSessionKey(userID, sessionID)).Err()
+}
+
+// RevokeSession은 특정 sessionID(기기)의 refresh token만 무효화한다.
+func RevokeSession(userID int, sessionID string) error {
+	ctx := context.Background()
+	return config.Redis.Del(ctx, refreshSessionKey(userID, sessionID)).Err()
+}
+
+// ListRefreshSessions는 사용자의 활성 세션(기기) 목록을 반환한다.
+func ListRefreshSessions(userID int) ([]RefreshSessionView, error) {
+	ctx := context.Background()
+	prefix := fmt.Sprintf("refresh:%d:", userID)
+
+	var sessions []RefreshSessionView
+	iter := config.Redis.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		data, err := config.Redis.Get(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+
+		var s RefreshSession
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			continue
+		}
+
+		sessions = append(sessions, RefreshSessionView{
+			ID:        strings.TrimPrefix(key, prefix),
+			IssuedAt:  s.IssuedAt,
+			UserAgent: s.UserAgent,
+			IP:        s.IP,
+		})
+	}
+
+	return sessions, iter.Err()
+}
+
+func revokeAllSessions(userID int) error {
+	ctx := context.Background()
+	prefix := fmt.Sprintf("refresh:%d:", userID)
+
+	var keys []string
+	iter := config.Redis.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return config.Redis.Del(ctx, keys...).Err()
+}
+
+func revokeFamily(userID int, familyID string) {
+	ctx := context.Background()
+	prefix := fmt.Sprintf("refresh:%d:", userID)
+
+	iter := config.Redis.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		data, err := config.Redis.Get(ctx, key).Result()
+		if err != nil {
+			continue
+		}
+
+		var s RefreshSession
+		if json.Unmarshal([]byte(data), &s) == nil && s.FamilyID == familyID {
+			config.Redis.Del(ctx, key)
+		}
+	}
+}